@@ -0,0 +1,54 @@
+package ginmetrics
+
+var (
+	metricRequestTotal    = "gin_request_total"
+	metricRequestUVTotal  = "gin_request_uv_total"
+	metricURIRequestTotal = "gin_uri_request_total"
+	metricRequestBody     = "gin_request_body_total"
+	metricResponseBody    = "gin_response_body_total"
+	metricRequestDuration = "gin_request_duration"
+	metricSlowRequest     = "gin_slow_request_total"
+)
+
+// registerBuiltinMetrics registers the built-in gin_* metrics against m. It
+// wires in the configured exemplar extractor (see SetExemplarExtractor) on
+// gin_request_total and gin_request_duration only; the other built-ins
+// never carry exemplars. Later calls to SetExemplarExtractor have no
+// effect on metrics already registered.
+func (m *Monitor) registerBuiltinMetrics() error {
+	requestTotal := &Metric{
+		Type:        Counter,
+		Name:        metricRequestTotal,
+		Description: "all the server received request num.",
+		Labels:      []string{"method", "path", "code"},
+	}
+	requestDuration := &Metric{
+		Type:        Histogram,
+		Name:        metricRequestDuration,
+		Description: "the time server took to handle the request.",
+		Labels:      []string{"path"},
+		Buckets:     m.reqDuration,
+	}
+	if m.exemplarExtractor != nil {
+		requestTotal.UseExemplars = true
+		requestTotal.ExemplarLabels = m.exemplarExtractor
+		requestDuration.UseExemplars = true
+		requestDuration.ExemplarLabels = m.exemplarExtractor
+	}
+
+	builtins := []*Metric{
+		requestTotal,
+		{Type: Counter, Name: metricRequestUVTotal, Description: "all the server received ip num.", Labels: []string{}},
+		{Type: Counter, Name: metricURIRequestTotal, Description: "all the server received request num with every uri.", Labels: []string{"uri", "method", "code"}},
+		{Type: Counter, Name: metricRequestBody, Description: "the server received request body size, unit byte.", Labels: []string{}},
+		{Type: Counter, Name: metricResponseBody, Description: "the server send response body size, unit byte.", Labels: []string{}},
+		requestDuration,
+		{Type: Counter, Name: metricSlowRequest, Description: "the server handled slow requests counter.", Labels: []string{"uri", "method", "code"}},
+	}
+	for _, metric := range builtins {
+		if err := m.AddMetric(metric); err != nil {
+			return err
+		}
+	}
+	return nil
+}
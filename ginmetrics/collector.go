@@ -0,0 +1,23 @@
+package ginmetrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// AddCollector registers an arbitrary prometheus.Collector against this
+// Monitor's registry (see SetRegistry), exposing it on /debug/metrics
+// alongside the built-in metrics. Unlike AddMetric, c is not tracked in
+// GetMetric/SetMetricPrefix-style lookups; use it for collectors whose
+// label set changes over the process lifetime, e.g. per-tenant gauges or
+// per-queue depths, which don't fit the fixed MetricVec shape AddMetric
+// expects.
+func (m *Monitor) AddCollector(c prometheus.Collector) error {
+	return m.registerer().Register(c)
+}
+
+// DescribeByCollect is a helper for custom prometheus.Collector
+// implementations registered via AddCollector. It implements Describe by
+// calling Collect and sending the Desc of every resulting Metric on ch,
+// mirroring the pattern client_golang itself uses for collectors whose
+// metrics aren't known ahead of time.
+func DescribeByCollect(c prometheus.Collector, ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
@@ -0,0 +1,35 @@
+package ginmetrics
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExemplarExtractor produces the exemplar labels for the current request.
+// A nil or empty return value means no exemplar should be attached.
+type ExemplarExtractor func(c *gin.Context) prometheus.Labels
+
+// SetExemplarExtractor configures the extractor used to populate exemplars
+// for the built-in gin_request_duration and gin_request_total metrics. The
+// extractor is only consulted for metrics that opt in via
+// Metric.UseExemplars.
+func (m *Monitor) SetExemplarExtractor(extractor ExemplarExtractor) {
+	m.exemplarExtractor = extractor
+}
+
+// OTelExemplarExtractor is a ready-made ExemplarExtractor that pulls the
+// traceID/spanID off the OpenTelemetry span stored on the request context,
+// so exemplars link straight through to traces in Grafana. It returns nil
+// when the request context carries no valid span context; a valid but
+// non-recording span still yields labels.
+func OTelExemplarExtractor(c *gin.Context) prometheus.Labels {
+	span := trace.SpanContextFromContext(c.Request.Context())
+	if !span.IsValid() {
+		return nil
+	}
+	return prometheus.Labels{
+		"traceID": span.TraceID().String(),
+		"spanID":  span.SpanID().String(),
+	}
+}
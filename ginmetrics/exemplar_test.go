@@ -0,0 +1,74 @@
+package ginmetrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetricExemplarLabels(t *testing.T) {
+	tooLong := strings.Repeat("a", maxExemplarRunes)
+	anyCtx := &gin.Context{}
+
+	cases := []struct {
+		name         string
+		useExemplars bool
+		ctx          *gin.Context
+		extractor    func(c *gin.Context) prometheus.Labels
+		want         prometheus.Labels
+	}{
+		{
+			name:         "exemplars disabled",
+			useExemplars: false,
+			ctx:          anyCtx,
+			extractor:    func(c *gin.Context) prometheus.Labels { return prometheus.Labels{"a": "b"} },
+		},
+		{
+			name:         "nil context",
+			useExemplars: true,
+			ctx:          nil,
+			extractor:    func(c *gin.Context) prometheus.Labels { return prometheus.Labels{"a": "b"} },
+		},
+		{
+			name:         "no extractor configured",
+			useExemplars: true,
+			ctx:          anyCtx,
+		},
+		{
+			name:         "extractor returns nothing",
+			useExemplars: true,
+			ctx:          anyCtx,
+			extractor:    func(c *gin.Context) prometheus.Labels { return nil },
+		},
+		{
+			name:         "combined length exceeds the OpenMetrics limit",
+			useExemplars: true,
+			ctx:          anyCtx,
+			extractor:    func(c *gin.Context) prometheus.Labels { return prometheus.Labels{"k": tooLong} },
+		},
+		{
+			name:         "valid labels pass through",
+			useExemplars: true,
+			ctx:          anyCtx,
+			extractor:    func(c *gin.Context) prometheus.Labels { return prometheus.Labels{"traceID": "abc"} },
+			want:         prometheus.Labels{"traceID": "abc"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Metric{UseExemplars: tc.useExemplars, ExemplarLabels: tc.extractor}
+			got := m.exemplarLabels(tc.ctx)
+			if len(got) != len(tc.want) {
+				t.Fatalf("exemplarLabels() = %v, want %v", got, tc.want)
+			}
+			for k, v := range tc.want {
+				if got[k] != v {
+					t.Errorf("exemplarLabels()[%q] = %q, want %q", k, got[k], v)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package ginmetrics
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Expose registers the metrics exposition endpoint (see SetMetricPath) on r.
+func (m *Monitor) Expose(r gin.IRoutes) {
+	r.GET(m.metricPath, m.metricsHandler())
+}
+
+// metricsHandler serves the process's metrics, gathered from the
+// configured registry (see SetRegistry), defaulting to the global
+// Prometheus registry. It negotiates the exposition format off the Accept
+// header, since native histograms (see SetNativeHistogram) are not
+// representable in the text format and require a client that requests the
+// protobuf format explicitly.
+func (m *Monitor) metricsHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		mfs, err := m.gatherer().Gather()
+		if err != nil {
+			c.String(http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		format := expfmt.Negotiate(c.Request.Header)
+		c.Writer.Header().Set("Content-Type", string(format))
+		enc := expfmt.NewEncoder(c.Writer, format)
+		for _, mf := range mfs {
+			if err := enc.Encode(mf); err != nil {
+				c.String(http.StatusInternalServerError, err.Error())
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package ginmetrics
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// maxExemplarRunes is the OpenMetrics limit on the combined length of an
+// exemplar's label names and values, counted in runes.
+const maxExemplarRunes = 128
+
+// Metric is a struct that describes a Prometheus metric that is registered
+// against a Monitor.
+type Metric struct {
+	Type        MetricType
+	Name        string
+	Description string
+	Labels      []string
+	Buckets     []float64
+	Objectives  map[float64]float64
+
+	// UseExemplars enables attaching an exemplar to each observation/
+	// increment recorded for this metric. It only has an effect on
+	// Counter and Histogram metrics, which are the only types Prometheus
+	// currently supports exemplars for.
+	UseExemplars bool
+
+	// ExemplarLabels, when set, is called at record time to produce the
+	// exemplar labels for the current request. A nil or empty result
+	// means no exemplar is attached.
+	ExemplarLabels func(c *gin.Context) prometheus.Labels
+
+	vec prometheus.Collector
+
+	// monitor is set by Monitor.AddMetric, and lets the methods below
+	// route through Monitor.RecordWithContext (or Monitor.RecordGaugeAdd,
+	// for Inc/Add on a Gauge) so SetReporter changes where they end up. It is nil for the zero Metric{} GetMetric
+	// returns when a name isn't found.
+	monitor *Monitor
+}
+
+// SetGaugeValue set data for Gauge type Metric.
+func (m *Metric) SetGaugeValue(labelValues []string, value float64) error {
+	if m.Type != Gauge {
+		return errors.Errorf("metric '%s' not Gauge type", m.Name)
+	}
+	if m.monitor != nil {
+		return m.monitor.RecordWithContext(nil, m.Name, labelValues, value)
+	}
+	m.directSet(labelValues, value)
+	return nil
+}
+
+// Inc increases the value of a Counter or Gauge type metric by one. On a
+// Gauge, this goes through Monitor.RecordGaugeAdd (see Add for why that's a
+// separate path from SetGaugeValue's).
+func (m *Metric) Inc(labelValues []string) error {
+	return m.incWithContext(nil, labelValues)
+}
+
+// Add adds the given value to a Counter or Gauge type metric. Counter
+// additions are routed through the configured Reporter's Counter method
+// (see Monitor.SetReporter); Gauge additions are routed through its
+// GaugeAdd method instead of Gauge, since Gauge models an absolute value
+// (mirroring OTel's synchronous Gauge, which has no delta operation) and
+// would otherwise silently discard every Inc/Add against a Gauge once a
+// Reporter is configured. SetGaugeValue always goes through Gauge.
+func (m *Metric) Add(labelValues []string, value float64) error {
+	switch m.Type {
+	case Counter:
+		if m.monitor != nil {
+			return m.monitor.RecordWithContext(nil, m.Name, labelValues, value)
+		}
+		m.directAdd(labelValues, value)
+	case Gauge:
+		return m.addGauge(labelValues, value)
+	default:
+		return errors.Errorf("metric '%s' not Counter or Gauge type", m.Name)
+	}
+	return nil
+}
+
+// Observe records a value for a Histogram or Summary type metric.
+func (m *Metric) Observe(labelValues []string, value float64) error {
+	return m.observeWithContext(nil, labelValues, value)
+}
+
+// incWithContext is Inc, threading c through to Monitor.RecordWithContext
+// so an exemplar (see Monitor.SetExemplarExtractor) can be attached once
+// the increment reaches Prometheus. The gin middleware calls this directly
+// with the request's context; Metric.Inc calls it with nil.
+func (m *Metric) incWithContext(c *gin.Context, labelValues []string) error {
+	if m.Type == Counter && m.monitor != nil {
+		return m.monitor.RecordWithContext(c, m.Name, labelValues, 1)
+	}
+	switch m.Type {
+	case Counter:
+		m.directAddWithContext(c, labelValues, 1)
+	case Gauge:
+		return m.addGauge(labelValues, 1)
+	default:
+		return errors.Errorf("metric '%s' not Counter or Gauge type", m.Name)
+	}
+	return nil
+}
+
+// addGauge routes a relative Gauge adjustment through
+// Monitor.RecordGaugeAdd (and so through the configured Reporter's
+// GaugeAdd), or directly against the Prometheus collector when no monitor
+// is set (the zero Metric{} GetMetric returns for an unknown name).
+func (m *Metric) addGauge(labelValues []string, delta float64) error {
+	if m.monitor != nil {
+		return m.monitor.RecordGaugeAdd(m.Name, labelValues, delta)
+	}
+	m.directGaugeAdd(labelValues, delta)
+	return nil
+}
+
+// observeWithContext is Observe, threading c through to
+// Monitor.RecordWithContext for the same reason as incWithContext.
+func (m *Metric) observeWithContext(c *gin.Context, labelValues []string, value float64) error {
+	if (m.Type == Histogram || m.Type == Summary) && m.monitor != nil {
+		return m.monitor.RecordWithContext(c, m.Name, labelValues, value)
+	}
+	switch m.Type {
+	case Histogram:
+		m.directObserveWithContext(c, labelValues, value)
+	case Summary:
+		m.directSummaryObserve(labelValues, value)
+	default:
+		return errors.Errorf("metric '%s' not Histogram or Summary type", m.Name)
+	}
+	return nil
+}
+
+// directSet sets a Gauge directly against its Prometheus collector. This
+// is the path Monitor.RecordWithContext falls back to when no Reporter has
+// been configured.
+func (m *Metric) directSet(labelValues []string, value float64) {
+	m.vec.(*prometheus.GaugeVec).WithLabelValues(labelValues...).Set(value)
+}
+
+// directGaugeAdd adds delta to a Gauge directly against its Prometheus
+// collector. This is the path Monitor.RecordGaugeAdd falls back to when no
+// Reporter has been configured.
+func (m *Metric) directGaugeAdd(labelValues []string, delta float64) {
+	m.vec.(*prometheus.GaugeVec).WithLabelValues(labelValues...).Add(delta)
+}
+
+// directAdd adds value to a Counter directly against its Prometheus
+// collector, with no exemplar.
+func (m *Metric) directAdd(labelValues []string, value float64) {
+	m.vec.(*prometheus.CounterVec).WithLabelValues(labelValues...).Add(value)
+}
+
+// directAddWithContext is directAdd but attaches an exemplar when c
+// carries one and the metric opted in via UseExemplars. This is the
+// recording path Monitor.RecordWithContext falls back to when no Reporter
+// has been configured.
+func (m *Metric) directAddWithContext(c *gin.Context, labelValues []string, value float64) {
+	counter := m.vec.(*prometheus.CounterVec).WithLabelValues(labelValues...)
+	if labels := m.exemplarLabels(c); labels != nil {
+		counter.(prometheus.ExemplarAdder).AddWithExemplar(value, labels)
+		return
+	}
+	counter.Add(value)
+}
+
+// directObserveWithContext observes value on a Histogram directly against
+// its Prometheus collector, attaching an exemplar when c carries one and
+// the metric opted in via UseExemplars.
+// This is the recording path Monitor.RecordWithContext falls back to when
+// no Reporter has been configured.
+func (m *Metric) directObserveWithContext(c *gin.Context, labelValues []string, value float64) {
+	histogram := m.vec.(*prometheus.HistogramVec).WithLabelValues(labelValues...)
+	if labels := m.exemplarLabels(c); labels != nil {
+		histogram.(prometheus.ExemplarObserver).ObserveWithExemplar(value, labels)
+		return
+	}
+	histogram.Observe(value)
+}
+
+// directSummaryObserve observes value on a Summary directly against its
+// Prometheus collector; Summary has no exemplar support in Prometheus.
+func (m *Metric) directSummaryObserve(labelValues []string, value float64) {
+	m.vec.(*prometheus.SummaryVec).WithLabelValues(labelValues...).Observe(value)
+}
+
+// exemplarLabels returns the exemplar labels to attach for this observation,
+// or nil if exemplars are disabled, no context is available, the extractor
+// produced nothing, or the combined label name+value length exceeds the
+// OpenMetrics limit (in which case the exemplar is silently dropped rather
+// than letting the client library panic).
+func (m *Metric) exemplarLabels(c *gin.Context) prometheus.Labels {
+	if !m.UseExemplars || c == nil || m.ExemplarLabels == nil {
+		return nil
+	}
+	labels := m.ExemplarLabels(c)
+	if len(labels) == 0 {
+		return nil
+	}
+	runes := 0
+	for name, value := range labels {
+		runes += len([]rune(name)) + len([]rune(value))
+	}
+	if runes > maxExemplarRunes {
+		return nil
+	}
+	return labels
+}
+
+// labelMap zips labelValues with this metric's declared label names, for
+// Reporter implementations that key observations by label name rather than
+// position.
+func (m *Metric) labelMap(labelValues []string) map[string]string {
+	labels := make(map[string]string, len(m.Labels))
+	for i, label := range m.Labels {
+		if i < len(labelValues) {
+			labels[label] = labelValues[i]
+		}
+	}
+	return labels
+}
@@ -0,0 +1,102 @@
+package ginmetrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Use registers the built-in gin_* metrics (see registerBuiltinMetrics),
+// installs the middleware that records them on every request, and exposes
+// them on the configured metric path (see Expose).
+func (m *Monitor) Use(r gin.IRoutes) error {
+	if err := m.registerBuiltinMetrics(); err != nil {
+		return err
+	}
+	r.Use(m.monitorInterceptor)
+	m.Expose(r)
+	return nil
+}
+
+// monitorInterceptor records every built-in gin_* metric (see
+// registerBuiltinMetrics) for each non-excluded request, threading the
+// request's *gin.Context through to incWithContext/observeWithContext so
+// an exemplar extractor (see SetExemplarExtractor) can attach trace/span
+// labels to gin_request_total and gin_request_duration.
+func (m *Monitor) monitorInterceptor(c *gin.Context) {
+	if m.isExcluded(c.Request.URL.Path) {
+		c.Next()
+		return
+	}
+
+	m.recordUniqueVisitor(c.ClientIP())
+
+	start := time.Now()
+	c.Next()
+	latency := time.Since(start)
+
+	method := c.Request.Method
+	uri := c.Request.URL.Path
+	path := c.FullPath()
+	code := strconv.Itoa(c.Writer.Status())
+
+	if metric, ok := m.metrics[metricRequestTotal]; ok {
+		_ = metric.incWithContext(c, []string{method, path, code})
+	}
+	if metric, ok := m.metrics[metricURIRequestTotal]; ok {
+		_ = metric.Inc([]string{uri, method, code})
+	}
+	if metric, ok := m.metrics[metricRequestBody]; ok && c.Request.ContentLength > 0 {
+		_ = metric.Add(nil, float64(c.Request.ContentLength))
+	}
+	if metric, ok := m.metrics[metricResponseBody]; ok {
+		if size := c.Writer.Size(); size > 0 {
+			_ = metric.Add(nil, float64(size))
+		}
+	}
+	if metric, ok := m.metrics[metricRequestDuration]; ok {
+		_ = metric.observeWithContext(c, []string{path}, latency.Seconds())
+	}
+	if latency >= time.Duration(m.slowTime)*time.Second {
+		if metric, ok := m.metrics[metricSlowRequest]; ok {
+			_ = metric.Inc([]string{uri, method, code})
+		}
+	}
+}
+
+// recordUniqueVisitor increments gin_request_uv_total the first time ip is
+// seen, and is a no-op on every later request from that same ip for the
+// rest of the process's lifetime. uvSeen is never evicted, so a
+// long-running process serving many distinct client IPs will grow it
+// without bound; restart the process (or front it with a bounded set) if
+// that's a concern for your traffic.
+func (m *Monitor) recordUniqueVisitor(ip string) {
+	if ip == "" {
+		return
+	}
+	m.uvMu.Lock()
+	if m.uvSeen == nil {
+		m.uvSeen = make(map[string]struct{})
+	}
+	_, seen := m.uvSeen[ip]
+	if !seen {
+		m.uvSeen[ip] = struct{}{}
+	}
+	m.uvMu.Unlock()
+	if seen {
+		return
+	}
+	if metric, ok := m.metrics[metricRequestUVTotal]; ok {
+		_ = metric.Inc(nil)
+	}
+}
+
+func (m *Monitor) isExcluded(path string) bool {
+	for _, excluded := range m.excludePaths {
+		if excluded == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package ginmetrics
+
+import "time"
+
+// NativeHistogramOptions configures Prometheus native (sparse) histograms
+// for the built-in gin_request_duration metric and any user-supplied
+// Histogram-type metric registered afterwards. The Prometheus server must
+// be scraping with native-histograms enabled for these to be of any use,
+// and /debug/metrics must be scraped with an Accept header requesting the
+// protobuf exposition format (see Monitor.Expose), since native histograms
+// are not representable in the text format.
+type NativeHistogramOptions struct {
+	NativeHistogramBucketFactor     float64
+	NativeHistogramMaxBucketNumber  uint32
+	NativeHistogramMinResetDuration time.Duration
+	NativeHistogramZeroThreshold    float64
+}
+
+// SetNativeHistogram enables native histograms for Histogram type metrics
+// registered after this call. Classic Buckets given on the metric are kept
+// alongside the native buckets, giving the classic-plus-native dual mode
+// the client library supports.
+func (m *Monitor) SetNativeHistogram(opts NativeHistogramOptions) {
+	m.nativeHistogramOpts = &opts
+}
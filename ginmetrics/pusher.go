@@ -0,0 +1,90 @@
+package ginmetrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Pusher pushes this Monitor's metrics to a Prometheus Pushgateway, for
+// short-lived jobs (cron jobs, serverless functions) that exit before a
+// scrape would ever reach them.
+type Pusher struct {
+	pusher  *push.Pusher
+	lastErr error
+}
+
+// NewPusher returns a Pusher that pushes every metric in this Monitor's
+// registry (see SetRegistry) to the Pushgateway at url under the given job
+// name. Without SetRegistry that is the global default registry, including
+// anything else registered there. The registry is fixed when NewPusher is
+// called; a later SetRegistry does not change it.
+func (m *Monitor) NewPusher(url, job string) *Pusher {
+	return &Pusher{pusher: push.New(url, job).Gatherer(m.gatherer())}
+}
+
+// Grouping adds a grouping key/value pair identifying the pushed metrics,
+// e.g. an instance name or shard index. It returns the Pusher for
+// chaining.
+func (p *Pusher) Grouping(name, value string) *Pusher {
+	p.pusher = p.pusher.Grouping(name, value)
+	return p
+}
+
+// BasicAuth configures HTTP basic auth for the push requests. It returns
+// the Pusher for chaining.
+func (p *Pusher) BasicAuth(username, password string) *Pusher {
+	p.pusher = p.pusher.BasicAuth(username, password)
+	return p
+}
+
+// Push replaces all metrics under the configured job/grouping with the
+// Monitor's current values. ctx is honored for cancellation/timeout of the
+// underlying HTTP request.
+func (p *Pusher) Push(ctx context.Context) error {
+	p.lastErr = p.pusher.PushContext(ctx)
+	return p.lastErr
+}
+
+// Add pushes the Monitor's current values without deleting previously
+// pushed metrics under the same job/grouping that are no longer present.
+// ctx is honored for cancellation/timeout of the underlying HTTP request.
+func (p *Pusher) Add(ctx context.Context) error {
+	p.lastErr = p.pusher.AddContext(ctx)
+	return p.lastErr
+}
+
+// Delete removes all metrics under the configured job/grouping from the
+// Pushgateway. Unlike Push/Add, the underlying client_golang Pusher has no
+// context-aware delete, so this call cannot be cancelled or given a
+// timeout.
+func (p *Pusher) Delete() error {
+	p.lastErr = p.pusher.Delete()
+	return p.lastErr
+}
+
+// LastError returns the error, if any, from the most recent Push, Add, or
+// Delete call.
+func (p *Pusher) LastError() error {
+	return p.lastErr
+}
+
+// StartPeriodicPush pushes pusher on a fixed interval until ctx is done,
+// for long-running workers behind a NAT or otherwise unreachable for
+// scraping that still want push semantics. Push errors are swallowed;
+// check pusher.LastError() after the fact if needed.
+func (m *Monitor) StartPeriodicPush(ctx context.Context, pusher *Pusher, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = pusher.Push(ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,47 @@
+package ginmetrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestSetRegistryScopesMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := &Monitor{metrics: make(map[string]*Metric)}
+	m.SetRegistry(reg)
+
+	metric := &Metric{Type: Counter, Name: "test_registry_counter", Description: "test counter", Labels: []string{}}
+	if err := m.AddMetric(metric); err != nil {
+		t.Fatalf("AddMetric: %v", err)
+	}
+	if err := metric.Inc(nil); err != nil {
+		t.Fatalf("Inc: %v", err)
+	}
+
+	n, err := testutil.GatherAndCount(reg, "test_registry_counter")
+	if err != nil {
+		t.Fatalf("GatherAndCount: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("GatherAndCount(scoped registry) = %d, want 1", n)
+	}
+
+	if n, _ := testutil.GatherAndCount(prometheus.DefaultGatherer, "test_registry_counter"); n != 0 {
+		t.Errorf("metric leaked into the default registry: GatherAndCount = %d, want 0", n)
+	}
+}
+
+func TestSetRegistryNilRevertsToDefault(t *testing.T) {
+	m := &Monitor{metrics: make(map[string]*Metric)}
+	m.SetRegistry(prometheus.NewRegistry())
+	m.SetRegistry(nil)
+
+	if m.gatherer() != prometheus.DefaultGatherer {
+		t.Errorf("gatherer() after SetRegistry(nil) did not revert to the default gatherer")
+	}
+	if m.registerer() != prometheus.DefaultRegisterer {
+		t.Errorf("registerer() after SetRegistry(nil) did not revert to the default registerer")
+	}
+}
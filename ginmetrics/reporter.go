@@ -0,0 +1,106 @@
+package ginmetrics
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/gin-gonic/gin"
+)
+
+// Reporter decouples Monitor from any single metrics backend. Built-in and
+// user-supplied metrics are recorded through whichever Reporter is
+// currently configured, keyed by metric name and label set.
+type Reporter interface {
+	Counter(name string, labels map[string]string, value float64)
+	// Gauge records an absolute value, as set via Metric.SetGaugeValue.
+	Gauge(name string, labels map[string]string, value float64)
+	// GaugeAdd records a relative adjustment, as made via Metric.Inc/Add.
+	// It is a separate method from Gauge, rather than an isDelta flag on
+	// it, because most backends (including Prometheus itself) need to
+	// handle the two differently: an absolute Set can be written straight
+	// through, while a delta generally has to be folded into whatever
+	// value was last recorded.
+	GaugeAdd(name string, labels map[string]string, delta float64)
+	Histogram(name string, labels map[string]string, value float64)
+	Summary(name string, labels map[string]string, value float64)
+}
+
+// SetReporter swaps the backend Monitor records metrics through. The
+// default, when none is set, records directly against each metric's own
+// Prometheus collector (preserving the original behavior, including
+// exemplars); see NoopReporter and OTelReporter for alternatives.
+func (m *Monitor) SetReporter(r Reporter) {
+	m.reporter = r
+}
+
+// Record observes value against the named metric through the configured
+// Reporter (or its Prometheus-backed default), pairing labelValues in
+// order with the metric's own declared label names.
+func (m *Monitor) Record(name string, labelValues []string, value float64) error {
+	return m.RecordWithContext(nil, name, labelValues, value)
+}
+
+// RecordWithContext is Record, additionally threading c through to the
+// metric's exemplar extractor (see SetExemplarExtractor) when no Reporter
+// has been configured. The built-in middleware and Metric's own
+// Inc/Add/Observe/SetGaugeValue record through it, so SetReporter changes
+// where their observations end up; Inc/Add on a Gauge go through
+// RecordGaugeAdd instead.
+func (m *Monitor) RecordWithContext(c *gin.Context, name string, labelValues []string, value float64) error {
+	metric, ok := m.metrics[name]
+	if !ok {
+		return errors.Errorf("metric '%s' not existed.", name)
+	}
+
+	if m.reporter == nil {
+		switch metric.Type {
+		case Counter:
+			metric.directAddWithContext(c, labelValues, value)
+		case Gauge:
+			metric.directSet(labelValues, value)
+		case Histogram:
+			metric.directObserveWithContext(c, labelValues, value)
+		case Summary:
+			metric.directSummaryObserve(labelValues, value)
+		default:
+			return errors.Errorf("metric '%s' has unknown type.", name)
+		}
+		return nil
+	}
+
+	labels := metric.labelMap(labelValues)
+	switch metric.Type {
+	case Counter:
+		m.reporter.Counter(name, labels, value)
+	case Gauge:
+		m.reporter.Gauge(name, labels, value)
+	case Histogram:
+		m.reporter.Histogram(name, labels, value)
+	case Summary:
+		m.reporter.Summary(name, labels, value)
+	default:
+		return errors.Errorf("metric '%s' has unknown type.", name)
+	}
+	return nil
+}
+
+// RecordGaugeAdd adjusts the named Gauge metric by delta, through the
+// configured Reporter's GaugeAdd (or directly against the Prometheus
+// collector when no Reporter is set). This is the path Metric's Inc/Add
+// record through for a Gauge, kept separate from RecordWithContext because
+// Reporter.Gauge models an absolute value and has no delta operation to
+// route a relative adjustment through.
+func (m *Monitor) RecordGaugeAdd(name string, labelValues []string, delta float64) error {
+	metric, ok := m.metrics[name]
+	if !ok {
+		return errors.Errorf("metric '%s' not existed.", name)
+	}
+	if metric.Type != Gauge {
+		return errors.Errorf("metric '%s' not Gauge type", name)
+	}
+
+	if m.reporter == nil {
+		metric.directGaugeAdd(labelValues, delta)
+		return nil
+	}
+	m.reporter.GaugeAdd(name, metric.labelMap(labelValues), delta)
+	return nil
+}
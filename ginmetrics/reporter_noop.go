@@ -0,0 +1,16 @@
+package ginmetrics
+
+// NoopReporter is a Reporter that discards every observation, for tests
+// and environments where metrics collection should be disabled, without
+// call sites having to special-case a nil Monitor. Its methods allocate
+// nothing, but the recording path is not allocation-free: the middleware
+// still builds its label slices, and Monitor.RecordWithContext and
+// RecordGaugeAdd still build a label map for each observation before
+// calling it.
+type NoopReporter struct{}
+
+func (NoopReporter) Counter(name string, labels map[string]string, value float64)   {}
+func (NoopReporter) Gauge(name string, labels map[string]string, value float64)     {}
+func (NoopReporter) GaugeAdd(name string, labels map[string]string, delta float64)  {}
+func (NoopReporter) Histogram(name string, labels map[string]string, value float64) {}
+func (NoopReporter) Summary(name string, labels map[string]string, value float64)   {}
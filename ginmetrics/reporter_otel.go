@@ -0,0 +1,150 @@
+package ginmetrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// OTelReporter records metrics through the OpenTelemetry Go SDK instead of
+// client_golang, for users running an OTel Collector that can export to
+// any backend. Instruments are created lazily, the first time a given
+// metric name is observed; mu guards the instrument maps since gin serves
+// requests concurrently.
+type OTelReporter struct {
+	meter metric.Meter
+
+	mu          sync.Mutex
+	counters    map[string]metric.Float64Counter
+	gauges      map[string]metric.Float64Gauge
+	histograms  map[string]metric.Float64Histogram
+	gaugeValues map[string]float64
+}
+
+// NewOTelReporter returns a Reporter that records through meter.
+func NewOTelReporter(meter metric.Meter) *OTelReporter {
+	return &OTelReporter{
+		meter:       meter,
+		counters:    make(map[string]metric.Float64Counter),
+		gauges:      make(map[string]metric.Float64Gauge),
+		histograms:  make(map[string]metric.Float64Histogram),
+		gaugeValues: make(map[string]float64),
+	}
+}
+
+func (r *OTelReporter) Counter(name string, labels map[string]string, value float64) {
+	r.mu.Lock()
+	c, ok := r.counters[name]
+	if !ok {
+		var err error
+		if c, err = r.meter.Float64Counter(name); err != nil {
+			r.mu.Unlock()
+			return
+		}
+		r.counters[name] = c
+	}
+	r.mu.Unlock()
+	c.Add(context.Background(), value, metric.WithAttributes(attributesOf(labels)...))
+}
+
+func (r *OTelReporter) Gauge(name string, labels map[string]string, value float64) {
+	r.mu.Lock()
+	r.gaugeValues[gaugeKey(name, labels)] = value
+	g, ok := r.gaugeInstrument(name)
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	g.Record(context.Background(), value, metric.WithAttributes(attributesOf(labels)...))
+}
+
+// GaugeAdd folds delta into the value last recorded for name+labels (0 if
+// this is the first observation) and records the new total. OTel's
+// synchronous Gauge instrument only supports recording an absolute value,
+// so unlike Counter/Histogram there is no instrument method to just hand
+// delta to.
+func (r *OTelReporter) GaugeAdd(name string, labels map[string]string, delta float64) {
+	r.mu.Lock()
+	key := gaugeKey(name, labels)
+	value := r.gaugeValues[key] + delta
+	r.gaugeValues[key] = value
+	g, ok := r.gaugeInstrument(name)
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+	g.Record(context.Background(), value, metric.WithAttributes(attributesOf(labels)...))
+}
+
+// gaugeInstrument returns (creating it if necessary) the Float64Gauge
+// instrument for name. Callers must hold r.mu.
+func (r *OTelReporter) gaugeInstrument(name string) (metric.Float64Gauge, bool) {
+	g, ok := r.gauges[name]
+	if ok {
+		return g, true
+	}
+	g, err := r.meter.Float64Gauge(name)
+	if err != nil {
+		return g, false
+	}
+	r.gauges[name] = g
+	return g, true
+}
+
+// gaugeKey derives a stable key for a gauge's running value from its name
+// and label set, so GaugeAdd can accumulate deltas per distinct label
+// combination rather than across all of them.
+func gaugeKey(name string, labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range keys {
+		b.WriteByte('\x00')
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(labels[k])
+	}
+	return b.String()
+}
+
+func (r *OTelReporter) Histogram(name string, labels map[string]string, value float64) {
+	r.observe(name, labels, value)
+}
+
+// Summary is recorded as a histogram, since OTel has no direct analogue of
+// a Prometheus summary.
+func (r *OTelReporter) Summary(name string, labels map[string]string, value float64) {
+	r.observe(name, labels, value)
+}
+
+func (r *OTelReporter) observe(name string, labels map[string]string, value float64) {
+	r.mu.Lock()
+	h, ok := r.histograms[name]
+	if !ok {
+		var err error
+		if h, err = r.meter.Float64Histogram(name); err != nil {
+			r.mu.Unlock()
+			return
+		}
+		r.histograms[name] = h
+	}
+	r.mu.Unlock()
+	h.Record(context.Background(), value, metric.WithAttributes(attributesOf(labels)...))
+}
+
+func attributesOf(labels map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for k, v := range labels {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
@@ -0,0 +1,130 @@
+package ginmetrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type spyReporter struct {
+	calls []string
+}
+
+func (s *spyReporter) Counter(name string, labels map[string]string, value float64) {
+	s.calls = append(s.calls, "counter:"+name)
+}
+func (s *spyReporter) Gauge(name string, labels map[string]string, value float64) {
+	s.calls = append(s.calls, "gauge:"+name)
+}
+func (s *spyReporter) GaugeAdd(name string, labels map[string]string, delta float64) {
+	s.calls = append(s.calls, "gaugeAdd:"+name)
+}
+func (s *spyReporter) Histogram(name string, labels map[string]string, value float64) {
+	s.calls = append(s.calls, "histogram:"+name)
+}
+func (s *spyReporter) Summary(name string, labels map[string]string, value float64) {
+	s.calls = append(s.calls, "summary:"+name)
+}
+
+func newTestMonitor() *Monitor {
+	m := &Monitor{metrics: make(map[string]*Metric)}
+	m.SetRegistry(prometheus.NewRegistry())
+	return m
+}
+
+func TestRecordRoutesThroughConfiguredReporter(t *testing.T) {
+	m := newTestMonitor()
+	metric := &Metric{Type: Counter, Name: "test_reporter_counter", Labels: []string{"label"}}
+	if err := m.AddMetric(metric); err != nil {
+		t.Fatalf("AddMetric: %v", err)
+	}
+
+	spy := &spyReporter{}
+	m.SetReporter(spy)
+	if err := m.Record("test_reporter_counter", []string{"v"}, 1); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if want := "counter:test_reporter_counter"; len(spy.calls) != 1 || spy.calls[0] != want {
+		t.Errorf("reporter calls = %v, want [%s]", spy.calls, want)
+	}
+	// With a Reporter configured, RecordWithContext never calls
+	// WithLabelValues on the underlying CounterVec, so no child counter is
+	// ever created and the Prometheus collector has nothing to gather: the
+	// Reporter fully replaces the Prometheus-backed recording path rather
+	// than supplementing it.
+	if n, _ := testutil.GatherAndCount(m.gatherer(), "test_reporter_counter"); n != 0 {
+		t.Errorf("GatherAndCount = %d, want 0", n)
+	}
+}
+
+func TestRecordDefaultsToDirectRecording(t *testing.T) {
+	m := newTestMonitor()
+	metric := &Metric{Type: Counter, Name: "test_direct_counter", Labels: []string{}}
+	if err := m.AddMetric(metric); err != nil {
+		t.Fatalf("AddMetric: %v", err)
+	}
+
+	if err := m.Record("test_direct_counter", nil, 3); err != nil {
+		t.Fatalf("Record: %v", err)
+	}
+
+	if got := testutil.ToFloat64(metric.vec.(*prometheus.CounterVec).WithLabelValues()); got != 3 {
+		t.Errorf("ToFloat64 = %v, want 3", got)
+	}
+}
+
+func TestRecordUnknownMetricErrors(t *testing.T) {
+	m := newTestMonitor()
+	if err := m.Record("does_not_exist", nil, 1); err == nil {
+		t.Fatal("Record on an unregistered metric name should error")
+	}
+}
+
+func TestGaugeIncAddRouteThroughReporterGaugeAdd(t *testing.T) {
+	m := newTestMonitor()
+	metric := &Metric{Type: Gauge, Name: "test_reporter_gauge", Labels: []string{}}
+	if err := m.AddMetric(metric); err != nil {
+		t.Fatalf("AddMetric: %v", err)
+	}
+
+	spy := &spyReporter{}
+	m.SetReporter(spy)
+
+	if err := metric.Inc(nil); err != nil {
+		t.Fatalf("Inc: %v", err)
+	}
+	if err := metric.Add(nil, 2); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	want := []string{"gaugeAdd:test_reporter_gauge", "gaugeAdd:test_reporter_gauge"}
+	if len(spy.calls) != len(want) {
+		t.Fatalf("reporter calls = %v, want %v", spy.calls, want)
+	}
+	for i, call := range want {
+		if spy.calls[i] != call {
+			t.Errorf("reporter calls[%d] = %q, want %q", i, spy.calls[i], call)
+		}
+	}
+}
+
+func TestGaugeIncAddDefaultToDirectRecording(t *testing.T) {
+	m := newTestMonitor()
+	metric := &Metric{Type: Gauge, Name: "test_direct_gauge", Labels: []string{}}
+	if err := m.AddMetric(metric); err != nil {
+		t.Fatalf("AddMetric: %v", err)
+	}
+
+	if err := metric.Inc(nil); err != nil {
+		t.Fatalf("Inc: %v", err)
+	}
+	if err := metric.Add(nil, 2); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	if got := testutil.ToFloat64(metric.vec.(*prometheus.GaugeVec).WithLabelValues()); got != 3 {
+		t.Errorf("ToFloat64 = %v, want 3", got)
+	}
+}
@@ -1,6 +1,8 @@
 package ginmetrics
 
 import (
+	"sync"
+
 	"github.com/cockroachdb/errors"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -23,7 +25,7 @@ var (
 	defaultDuration     = []float64{0.1, 0.3, 1.2, 5, 10}
 	monitor             *Monitor
 
-	promTypeHandler = map[MetricType]func(metric *Metric) error{
+	promTypeHandler = map[MetricType]func(m *Monitor, metric *Metric) error{
 		Counter:   counterHandler,
 		Gauge:     gaugeHandler,
 		Histogram: histogramHandler,
@@ -39,6 +41,30 @@ type Monitor struct {
 	reqDuration  []float64
 	metrics      map[string]*Metric
 	metadata     map[string]string
+
+	// exemplarExtractor, when set, is consulted by the built-in metrics
+	// to attach an exemplar to each observation. See SetExemplarExtractor.
+	exemplarExtractor ExemplarExtractor
+
+	// nativeHistogramOpts, when set, is applied to every Histogram type
+	// metric registered afterwards. See SetNativeHistogram.
+	nativeHistogramOpts *NativeHistogramOptions
+
+	// registry, when set, is used in place of the global default registry
+	// and gatherer. See SetRegistry.
+	registry *prometheus.Registry
+
+	// reporter, when set, is used in place of the default Prometheus-
+	// backed recording path. See SetReporter.
+	reporter Reporter
+
+	// uvMu guards uvSeen, which the gin middleware consults to only count
+	// each client IP once towards gin_request_uv_total, for the lifetime
+	// of the process; it is never evicted. Lazily initialized, so a
+	// Monitor built as a struct literal instead of via GetMonitor still
+	// works.
+	uvMu   sync.Mutex
+	uvSeen map[string]struct{}
 }
 
 // GetMonitor used to get global Monitor object,
@@ -52,6 +78,7 @@ func GetMonitor() *Monitor {
 			reqDuration:  defaultDuration,
 			metrics:      make(map[string]*Metric),
 			metadata:     make(map[string]string),
+			uvSeen:       make(map[string]struct{}),
 		}
 	}
 	return monitor
@@ -118,8 +145,11 @@ func (m *Monitor) AddMetric(metric *Metric) error {
 		return errors.Errorf("metric name cannot be empty.")
 	}
 	if f, ok := promTypeHandler[metric.Type]; ok {
-		if err := f(metric); err == nil {
-			prometheus.MustRegister(metric.vec)
+		if err := f(m, metric); err == nil {
+			if err := m.registerer().Register(metric.vec); err != nil {
+				return err
+			}
+			metric.monitor = m
 			m.metrics[metric.Name] = metric
 			return nil
 		}
@@ -127,7 +157,7 @@ func (m *Monitor) AddMetric(metric *Metric) error {
 	return errors.Errorf("metric type '%d' not existed.", metric.Type)
 }
 
-func counterHandler(metric *Metric) error {
+func counterHandler(m *Monitor, metric *Metric) error {
 	metric.vec = prometheus.NewCounterVec(
 		prometheus.CounterOpts{Name: metric.Name, Help: metric.Description},
 		metric.Labels,
@@ -135,7 +165,7 @@ func counterHandler(metric *Metric) error {
 	return nil
 }
 
-func gaugeHandler(metric *Metric) error {
+func gaugeHandler(m *Monitor, metric *Metric) error {
 	metric.vec = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{Name: metric.Name, Help: metric.Description},
 		metric.Labels,
@@ -143,18 +173,22 @@ func gaugeHandler(metric *Metric) error {
 	return nil
 }
 
-func histogramHandler(metric *Metric) error {
-	if len(metric.Buckets) == 0 {
+func histogramHandler(m *Monitor, metric *Metric) error {
+	opts := prometheus.HistogramOpts{Name: metric.Name, Help: metric.Description, Buckets: metric.Buckets}
+	if m.nativeHistogramOpts != nil {
+		opts.NativeHistogramBucketFactor = m.nativeHistogramOpts.NativeHistogramBucketFactor
+		opts.NativeHistogramMaxBucketNumber = m.nativeHistogramOpts.NativeHistogramMaxBucketNumber
+		opts.NativeHistogramMinResetDuration = m.nativeHistogramOpts.NativeHistogramMinResetDuration
+		opts.NativeHistogramZeroThreshold = m.nativeHistogramOpts.NativeHistogramZeroThreshold
+	}
+	if len(metric.Buckets) == 0 && m.nativeHistogramOpts == nil {
 		return errors.Errorf("metric '%s' is histogram type, cannot lose bucket param.", metric.Name)
 	}
-	metric.vec = prometheus.NewHistogramVec(
-		prometheus.HistogramOpts{Name: metric.Name, Help: metric.Description, Buckets: metric.Buckets},
-		metric.Labels,
-	)
+	metric.vec = prometheus.NewHistogramVec(opts, metric.Labels)
 	return nil
 }
 
-func summaryHandler(metric *Metric) error {
+func summaryHandler(m *Monitor, metric *Metric) error {
 	if len(metric.Objectives) == 0 {
 		return errors.Errorf("metric '%s' is summary type, cannot lose objectives param.", metric.Name)
 	}